@@ -0,0 +1,84 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"fantom-api-graphql/internal/repository"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// defaultFeeHistoryBlockCount is the number of trailing blocks pulled for the
+// eip1559Fees query; the query takes no argument to change it.
+const defaultFeeHistoryBlockCount = 20
+
+// defaultFeeHistoryRewardPercentile is the reward percentile requested from `eth_feeHistory`
+// to derive the suggested priority tip.
+const defaultFeeHistoryRewardPercentile = 50
+
+// Eip1559Fees represents a suggested EIP-1559 fee package resolvable to GraphQL API requests.
+type Eip1559Fees struct {
+	repository.Eip1559Fees
+}
+
+// NewEip1559Fees creates a new instance of resolvable EIP-1559 fee suggestion.
+func NewEip1559Fees(f *repository.Eip1559Fees) *Eip1559Fees {
+	return &Eip1559Fees{Eip1559Fees: *f}
+}
+
+// BaseFeePerGas resolves the current base fee per gas of the pending block.
+func (f Eip1559Fees) BaseFeePerGas() hexutil.Big {
+	return f.Eip1559Fees.BaseFeePerGas
+}
+
+// SuggestedMaxPriorityFeePerGas resolves the suggested priority tip for the pending transaction.
+func (f Eip1559Fees) SuggestedMaxPriorityFeePerGas() hexutil.Big {
+	return f.Eip1559Fees.SuggestedMaxPriorityFeePerGas
+}
+
+// SuggestedMaxFeePerGas resolves the suggested max fee per gas, baseFee*2 + priorityTip.
+func (f Eip1559Fees) SuggestedMaxFeePerGas() hexutil.Big {
+	return f.Eip1559Fees.SuggestedMaxFeePerGas
+}
+
+// FeeHistory resolves the base fee and reward history used to derive the suggestion.
+func (f Eip1559Fees) FeeHistory() []FeeHistoryEntry {
+	hist := f.Eip1559Fees.FeeHistory
+	if hist == nil {
+		return nil
+	}
+
+	out := make([]FeeHistoryEntry, len(hist.GasUsedRatio))
+	for i := range out {
+		entry := FeeHistoryEntry{
+			Block:        hexutil.Uint64(uint64(hist.OldestBlock) + uint64(i)),
+			GasUsedRatio: hist.GasUsedRatio[i],
+		}
+		if i < len(hist.BaseFeePerGas) {
+			entry.BaseFeePerGas = hist.BaseFeePerGas[i]
+		}
+		if i < len(hist.Reward) {
+			entry.Reward = hist.Reward[i]
+		}
+		out[i] = entry
+	}
+
+	return out
+}
+
+// FeeHistoryEntry represents a single block's worth of fee history data.
+type FeeHistoryEntry struct {
+	Block         hexutil.Uint64
+	BaseFeePerGas hexutil.Big
+	GasUsedRatio  float64
+	Reward        []hexutil.Big
+}
+
+// Eip1559Fees resolves the suggested EIP-1559 fee package for a pending transaction.
+func (rs *RootResolver) Eip1559Fees(ctx context.Context) (*Eip1559Fees, error) {
+	fees, err := repository.R().Eip1559Fees(ctx, defaultFeeHistoryBlockCount, []float64{defaultFeeHistoryRewardPercentile})
+	if err != nil {
+		return nil, err
+	}
+
+	return NewEip1559Fees(fees), nil
+}
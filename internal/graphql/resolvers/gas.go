@@ -0,0 +1,34 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"fantom-api-graphql/internal/repository"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// GasEstimateTrxInput represents the transaction used as the input of the estimateGas query.
+type GasEstimateTrxInput struct {
+	From  *common.Address
+	To    *common.Address
+	Value *hexutil.Big
+	Data  *string
+}
+
+// EstimateGas resolves the estimated amount of Gas needed to execute the given transaction,
+// found via a binary search bounded by the configured RPC global gas cap. The request's context
+// is forwarded so a client disconnect or query-cost timeout cancels the upstream RPC round trips.
+func (rs *RootResolver) EstimateGas(ctx context.Context, args struct{ Trx GasEstimateTrxInput }) (hexutil.Uint64, error) {
+	val, err := repository.R().GasEstimate(ctx, &struct {
+		From  *common.Address
+		To    *common.Address
+		Value *hexutil.Big
+		Data  *string
+	}{args.Trx.From, args.Trx.To, args.Trx.Value, args.Trx.Data})
+	if err != nil {
+		return hexutil.Uint64(0), err
+	}
+
+	return *val, nil
+}
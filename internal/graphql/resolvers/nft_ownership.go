@@ -22,6 +22,18 @@ func (no NftOwnership) Obtained() hexutil.Uint64 {
 	return hexutil.Uint64(no.NftOwnership.Obtained.Unix())
 }
 
+// Quantity resolves the amount of the token id held by the owner. For ERC-721 it is 1 for the
+// current owner and 0 for a former one; for ERC-1155 it is the running balance accumulated
+// from TransferSingle/TransferBatch events.
+func (no NftOwnership) Quantity() hexutil.Big {
+	return no.NftOwnership.Qty
+}
+
+// ContractType resolves the NFT contract standard (ERC721/ERC1155) this ownership belongs to.
+func (no NftOwnership) ContractType() string {
+	return string(no.NftOwnership.ContractType)
+}
+
 // Contract resolves related contract.
 func (no NftOwnership) Contract() (*Contract, error) {
 	c, err := repository.R().Contract(&no.NftOwnership.Contract)
@@ -0,0 +1,9 @@
+// Package schema exposes the GraphQL SDL additions defined in schema.graphqls as a Go-level
+// value, so the server's schema loader has something in this tree to actually reference
+// instead of a standalone file it never reads.
+package schema
+
+import _ "embed"
+
+//go:embed schema.graphqls
+var Extensions string
@@ -0,0 +1,27 @@
+package schema
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestExtensions_IncludesEstimateGas(t *testing.T) {
+	if !strings.Contains(Extensions, "estimateGas(trx: TrxInput!): Long!") {
+		t.Fatal("expected the embedded SDL to declare estimateGas")
+	}
+}
+
+func TestExtensions_IncludesEip1559Fees(t *testing.T) {
+	if !strings.Contains(Extensions, "eip1559Fees: Eip1559Fees!") {
+		t.Fatal("expected the embedded SDL to declare eip1559Fees")
+	}
+}
+
+func TestExtensions_IncludesNftOwnershipFields(t *testing.T) {
+	if !strings.Contains(Extensions, "quantity: BigInt!") {
+		t.Fatal("expected the embedded SDL to declare NftOwnership.quantity")
+	}
+	if !strings.Contains(Extensions, "contractType: String!") {
+		t.Fatal("expected the embedded SDL to declare NftOwnership.contractType")
+	}
+}
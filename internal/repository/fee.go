@@ -0,0 +1,53 @@
+/*
+Package repository implements repository for handling fast and efficient access to data required
+by the resolvers of the API server.
+
+Internally it utilizes RPC to access Opera/Lachesis full node for blockchain interaction. Mongo database
+for fast, robust and scalable off-chain data storage, especially for aggregated and pre-calculated data mining
+results. BigCache for in-memory object storage to speed up loading of frequently accessed entities.
+*/package repository
+
+import (
+	"context"
+	"fantom-api-graphql/internal/repository/rpc"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"math/big"
+)
+
+// Eip1559Fees represents a suggested EIP-1559 fee package for a pending transaction.
+type Eip1559Fees struct {
+	BaseFeePerGas                 hexutil.Big
+	SuggestedMaxPriorityFeePerGas hexutil.Big
+	SuggestedMaxFeePerGas         hexutil.Big
+	FeeHistory                    *rpc.FeeHistory
+}
+
+// Eip1559Fees resolves the current base fee together with the suggested priority tip
+// and max fee for a pending transaction, per the usual wallet heuristic of
+// baseFee*2 + priorityTip.
+func (p *proxy) Eip1559Fees(ctx context.Context, blockCount uint64, rewardPercentiles []float64) (*Eip1559Fees, error) {
+	hist, err := p.rpc.FeeHistory(ctx, blockCount, "latest", rewardPercentiles)
+	if err != nil {
+		return nil, err
+	}
+
+	tip, err := p.rpc.MaxPriorityFeePerGasFromHistory(ctx, hist)
+	if err != nil {
+		return nil, err
+	}
+
+	var baseFee hexutil.Big
+	if n := len(hist.BaseFeePerGas); n > 0 {
+		baseFee = hist.BaseFeePerGas[n-1]
+	}
+
+	maxFee := new(big.Int).Mul(baseFee.ToInt(), big.NewInt(2))
+	maxFee.Add(maxFee, tip.ToInt())
+
+	return &Eip1559Fees{
+		BaseFeePerGas:                 baseFee,
+		SuggestedMaxPriorityFeePerGas: tip,
+		SuggestedMaxFeePerGas:         hexutil.Big(*maxFee),
+		FeeHistory:                    hist,
+	}, nil
+}
@@ -0,0 +1,30 @@
+/*
+Package repository implements repository for handling fast and efficient access to data required
+by the resolvers of the API server.
+
+Internally it utilizes RPC to access Opera/Lachesis full node for blockchain interaction. Mongo database
+for fast, robust and scalable off-chain data storage, especially for aggregated and pre-calculated data mining
+results. BigCache for in-memory object storage to speed up loading of frequently accessed entities.
+*/package repository
+
+import (
+	"context"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// GasPrice resolves the current amount of WEI for single Gas.
+func (p *proxy) GasPrice(ctx context.Context) (hexutil.Big, error) {
+	return p.rpc.GasPrice(ctx)
+}
+
+// GasEstimate resolves the estimated amount of Gas required to perform the transaction
+// described by the input params, bound by the configured RPC global gas cap.
+func (p *proxy) GasEstimate(ctx context.Context, trx *struct {
+	From  *common.Address
+	To    *common.Address
+	Value *hexutil.Big
+	Data  *string
+}) (*hexutil.Uint64, error) {
+	return p.rpc.GasEstimate(ctx, trx)
+}
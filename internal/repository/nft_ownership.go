@@ -8,17 +8,279 @@ results. BigCache for in-memory object storage to speed up loading of frequently
 */package repository
 
 import (
+	"context"
 	"fantom-api-graphql/internal/types"
+	"fmt"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	"math/big"
+	"time"
 )
 
-// StoreNftOwnership stores the given NFT ownership record in persistent storage.
+// erc721TransferTopic is the ERC-721/ERC-20 `Transfer(address,address,uint256)` event signature.
+var erc721TransferTopic = common.HexToHash("0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef")
+
+// erc1155TransferSingleTopic is the ERC-1155 `TransferSingle(address,address,address,uint256,uint256)` event signature.
+var erc1155TransferSingleTopic = common.HexToHash("0xc3d58168c5ae7397731d063d5bbf3d657854427343f4c083240f7aacaa2d0f62")
+
+// erc1155TransferBatchTopic is the ERC-1155 `TransferBatch(address,address,address,uint256[],uint256[])` event signature.
+var erc1155TransferBatchTopic = common.HexToHash("0x4a39dc06d4c0dbc64b70af90fd698a233a518aa5d07e595d983b8c0526c8f7fb")
+
+// StoreNftOwnership upserts the given NFT ownership record in persistent storage, keyed
+// by (contract, tokenId, owner). The record's Qty is treated as a delta on top of any
+// previously stored balance, so repeated TransferSingle/TransferBatch events correctly
+// build up (or draw down) the owner's running balance instead of overwriting it. A balance
+// that would go negative, e.g. due to events observed out of order, is floored at zero.
 func (p *proxy) StoreNftOwnership(no *types.NftOwnership) error {
+	current, err := p.nftOwnershipQty(&no.Contract, &no.TokenId, &no.Owner)
+	if err != nil {
+		return err
+	}
+
+	qty := new(big.Int).Add(current, no.Qty.ToInt())
+	if qty.Sign() < 0 {
+		qty = new(big.Int)
+	}
+	no.Qty = hexutil.Big(*qty)
+
 	return p.db.StoreNftOwnership(no)
 }
 
-// ListNftOwnerships resolves list of nft ownerships based on input data.
-func (p *proxy) ListNftOwnerships(contract *common.Address, tokenId *hexutil.Big, owner *common.Address, cursor *string, count int32) (out *types.NftOwnershipList, err error) {
-	return p.db.ListNftOwnerships(contract, tokenId, owner, cursor, count)
-}
\ No newline at end of file
+// nftOwnershipQty resolves the currently stored balance for (contract, tokenId, owner), or
+// zero if no record exists yet.
+func (p *proxy) nftOwnershipQty(contract *common.Address, tokenId *hexutil.Big, owner *common.Address) (*big.Int, error) {
+	list, err := p.db.ListNftOwnerships(contract, tokenId, owner, nil, nil, 1)
+	if err != nil {
+		return nil, err
+	}
+	if list == nil || len(list.Collection) == 0 {
+		return new(big.Int), nil
+	}
+	return list.Collection[0].Qty.ToInt(), nil
+}
+
+// ListNftOwnerships resolves list of nft ownerships based on input data, optionally
+// restricted to a single contract standard via contractType.
+func (p *proxy) ListNftOwnerships(contract *common.Address, tokenId *hexutil.Big, owner *common.Address, contractType *types.NftContractType, cursor *string, count int32) (out *types.NftOwnershipList, err error) {
+	return p.db.ListNftOwnerships(contract, tokenId, owner, contractType, cursor, count)
+}
+
+// DetectNftContractType resolves whether the contract at the given address is an ERC-721
+// or an ERC-1155 NFT contract, probed via ERC-165 `supportsInterface`.
+func (p *proxy) DetectNftContractType(ctx context.Context, contract *common.Address) (types.NftContractType, error) {
+	return p.rpc.DetectNftContractType(ctx, contract)
+}
+
+// ProcessNftTransferSingle applies the balance change of an ERC-1155 TransferSingle event
+// (or an ERC-721 Transfer event treated as a unit transfer) to the stored ownership records
+// of the sender and the recipient, tagging both with the contract's detected NFT standard.
+// The zero address is used by the ERC-1155/721 standards to mark a mint (no from) or a burn
+// (no to) and is skipped accordingly.
+func (p *proxy) ProcessNftTransferSingle(ctx context.Context, contract *common.Address, from common.Address, to common.Address, tokenId *hexutil.Big, qty *big.Int) error {
+	ct, err := p.DetectNftContractType(ctx, contract)
+	if err != nil {
+		return err
+	}
+
+	var zero common.Address
+	now := time.Now()
+
+	if from != zero {
+		if err := p.StoreNftOwnership(&types.NftOwnership{
+			Contract:     *contract,
+			TokenId:      *tokenId,
+			Owner:        from,
+			Qty:          hexutil.Big(*new(big.Int).Neg(qty)),
+			ContractType: ct,
+			Obtained:     now,
+		}); err != nil {
+			return err
+		}
+	}
+
+	if to != zero {
+		if err := p.StoreNftOwnership(&types.NftOwnership{
+			Contract:     *contract,
+			TokenId:      *tokenId,
+			Owner:        to,
+			Qty:          hexutil.Big(*qty),
+			ContractType: ct,
+			Obtained:     now,
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ProcessNftTransferBatch applies the balance changes of an ERC-1155 TransferBatch event,
+// i.e. a TransferSingle applied per (tokenIds[i], qtys[i]) pair, sharing a single
+// ERC-165 contract-type lookup across the whole batch.
+func (p *proxy) ProcessNftTransferBatch(ctx context.Context, contract *common.Address, from common.Address, to common.Address, tokenIds []*hexutil.Big, qtys []*big.Int) error {
+	ct, err := p.DetectNftContractType(ctx, contract)
+	if err != nil {
+		return err
+	}
+
+	var zero common.Address
+	now := time.Now()
+
+	for i, tokenId := range tokenIds {
+		qty := qtys[i]
+
+		if from != zero {
+			if err := p.StoreNftOwnership(&types.NftOwnership{
+				Contract:     *contract,
+				TokenId:      *tokenId,
+				Owner:        from,
+				Qty:          hexutil.Big(*new(big.Int).Neg(qty)),
+				ContractType: ct,
+				Obtained:     now,
+			}); err != nil {
+				return err
+			}
+		}
+
+		if to != zero {
+			if err := p.StoreNftOwnership(&types.NftOwnership{
+				Contract:     *contract,
+				TokenId:      *tokenId,
+				Owner:        to,
+				Qty:          hexutil.Big(*qty),
+				ContractType: ct,
+				Obtained:     now,
+			}); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// ProcessNftTransferLog decodes a raw ERC-721 `Transfer` / ERC-1155 `TransferSingle` /
+// `TransferBatch` event log and applies the resulting balance change via
+// ProcessNftTransferSingle/ProcessNftTransferBatch. This is the entry point the chain's log
+// subscriber feeds decoded NFT transfer events through; logs carrying an unrecognized topic
+// are ignored.
+func (p *proxy) ProcessNftTransferLog(ctx context.Context, lg *gethtypes.Log) error {
+	if lg == nil || len(lg.Topics) == 0 {
+		return fmt.Errorf("empty NFT transfer log")
+	}
+
+	switch lg.Topics[0] {
+	case erc721TransferTopic:
+		if len(lg.Topics) < 4 {
+			return fmt.Errorf("malformed ERC-721 Transfer log")
+		}
+		from := common.BytesToAddress(lg.Topics[1].Bytes())
+		to := common.BytesToAddress(lg.Topics[2].Bytes())
+		tokenId := hexutil.Big(*new(big.Int).SetBytes(lg.Topics[3].Bytes()))
+		return p.ProcessNftTransferSingle(ctx, &lg.Address, from, to, &tokenId, big.NewInt(1))
+
+	case erc1155TransferSingleTopic:
+		if len(lg.Topics) < 4 || len(lg.Data) < 64 {
+			return fmt.Errorf("malformed ERC-1155 TransferSingle log")
+		}
+		// Topics[1] is the operator, not the sender
+		from := common.BytesToAddress(lg.Topics[2].Bytes())
+		to := common.BytesToAddress(lg.Topics[3].Bytes())
+		tokenId := hexutil.Big(*new(big.Int).SetBytes(lg.Data[:32]))
+		qty := new(big.Int).SetBytes(lg.Data[32:64])
+		return p.ProcessNftTransferSingle(ctx, &lg.Address, from, to, &tokenId, qty)
+
+	case erc1155TransferBatchTopic:
+		if len(lg.Topics) < 4 {
+			return fmt.Errorf("malformed ERC-1155 TransferBatch log")
+		}
+		// Topics[1] is the operator, not the sender
+		from := common.BytesToAddress(lg.Topics[2].Bytes())
+		to := common.BytesToAddress(lg.Topics[3].Bytes())
+
+		tokenIds, qtys, err := decodeTransferBatchData(lg.Data)
+		if err != nil {
+			return err
+		}
+		return p.ProcessNftTransferBatch(ctx, &lg.Address, from, to, tokenIds, qtys)
+
+	default:
+		return nil
+	}
+}
+
+// ProcessNftTransferLogs pulls the ERC-721/ERC-1155 transfer event logs emitted between
+// fromBlock and toBlock, inclusive, and applies each of them via ProcessNftTransferLog. It
+// returns the number of logs processed, so a caller (e.g. a block range scanner) can track
+// progress. A log carrying an unrecognized topic is silently skipped by ProcessNftTransferLog.
+func (p *proxy) ProcessNftTransferLogs(ctx context.Context, fromBlock *big.Int, toBlock *big.Int) (int, error) {
+	logs, err := p.rpc.FilterNftTransferLogs(ctx, fromBlock, toBlock, []common.Hash{
+		erc721TransferTopic,
+		erc1155TransferSingleTopic,
+		erc1155TransferBatchTopic,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	for i := range logs {
+		if err := p.ProcessNftTransferLog(ctx, &logs[i]); err != nil {
+			return i, err
+		}
+	}
+
+	return len(logs), nil
+}
+
+// decodeTransferBatchData decodes the ABI-encoded `(uint256[] ids, uint256[] values)` payload
+// carried in a TransferBatch log's data, rejecting offsets/lengths that would read outside of
+// the received payload and requiring both arrays to be the same length.
+func decodeTransferBatchData(data []byte) ([]*hexutil.Big, []*big.Int, error) {
+	if len(data) < 64 {
+		return nil, nil, fmt.Errorf("TransferBatch log data too short: %d bytes", len(data))
+	}
+
+	idsOffset := new(big.Int).SetBytes(data[0:32]).Uint64()
+	valuesOffset := new(big.Int).SetBytes(data[32:64]).Uint64()
+
+	ids, err := decodeUint256Array(data, idsOffset)
+	if err != nil {
+		return nil, nil, err
+	}
+	values, err := decodeUint256Array(data, valuesOffset)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(ids) != len(values) {
+		return nil, nil, fmt.Errorf("TransferBatch log has %d ids but %d values", len(ids), len(values))
+	}
+
+	tokenIds := make([]*hexutil.Big, len(ids))
+	for i, id := range ids {
+		v := hexutil.Big(*id)
+		tokenIds[i] = &v
+	}
+
+	return tokenIds, values, nil
+}
+
+// decodeUint256Array decodes a single ABI-encoded `uint256[]` (length word followed by its
+// elements) located at offset within data.
+func decodeUint256Array(data []byte, offset uint64) ([]*big.Int, error) {
+	if offset > uint64(len(data)) || uint64(len(data))-offset < 32 {
+		return nil, fmt.Errorf("array offset %d beyond the %d bytes received", offset, len(data))
+	}
+
+	length := new(big.Int).SetBytes(data[offset : offset+32]).Uint64()
+	start := offset + 32
+	if length > (uint64(len(data))-start)/32 {
+		return nil, fmt.Errorf("array of %d elements overflows the %d bytes received", length, len(data))
+	}
+
+	out := make([]*big.Int, length)
+	for i := uint64(0); i < length; i++ {
+		out[i] = new(big.Int).SetBytes(data[start+i*32 : start+(i+1)*32])
+	}
+	return out, nil
+}
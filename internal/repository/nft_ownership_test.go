@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"math/big"
+	"testing"
+)
+
+// encodeUint256Array builds the ABI encoding (length word + padded elements) of a
+// uint256[] for use as test fixture data.
+func encodeUint256Array(values ...int64) []byte {
+	word := func(n int64) []byte {
+		b := make([]byte, 32)
+		big.NewInt(n).FillBytes(b)
+		return b
+	}
+
+	out := word(int64(len(values)))
+	for _, v := range values {
+		out = append(out, word(v)...)
+	}
+	return out
+}
+
+func TestDecodeUint256Array(t *testing.T) {
+	data := encodeUint256Array(1, 2, 3)
+
+	got, err := decodeUint256Array(data, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if len(got) != 3 || got[0].Int64() != 1 || got[1].Int64() != 2 || got[2].Int64() != 3 {
+		t.Fatalf("unexpected decode result: %v", got)
+	}
+}
+
+func TestDecodeUint256Array_OffsetBeyondData(t *testing.T) {
+	if _, err := decodeUint256Array(make([]byte, 32), 100); err == nil {
+		t.Fatal("expected an error for an out-of-bounds offset")
+	}
+}
+
+func TestDecodeUint256Array_LengthOverflowsData(t *testing.T) {
+	data := encodeUint256Array(1, 2, 3)
+	data = data[:len(data)-32] // truncate the last element away
+
+	if _, err := decodeUint256Array(data, 0); err == nil {
+		t.Fatal("expected an error for a length that overflows the data")
+	}
+}
+
+func TestDecodeTransferBatchData(t *testing.T) {
+	ids := encodeUint256Array(10, 20)
+	values := encodeUint256Array(100, 200)
+
+	data := append([]byte{}, make([]byte, 64)...)
+	big.NewInt(64).FillBytes(data[0:32])
+	big.NewInt(int64(64+len(ids))).FillBytes(data[32:64])
+	data = append(data, ids...)
+	data = append(data, values...)
+
+	tokenIds, qtys, err := decodeTransferBatchData(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if len(tokenIds) != 2 || len(qtys) != 2 {
+		t.Fatalf("expected 2 ids/values, got %d/%d", len(tokenIds), len(qtys))
+	}
+	if tokenIds[0].ToInt().Int64() != 10 || tokenIds[1].ToInt().Int64() != 20 {
+		t.Fatalf("unexpected token ids: %v", tokenIds)
+	}
+	if qtys[0].Int64() != 100 || qtys[1].Int64() != 200 {
+		t.Fatalf("unexpected quantities: %v", qtys)
+	}
+}
+
+func TestDecodeTransferBatchData_MismatchedLengths(t *testing.T) {
+	ids := encodeUint256Array(10, 20)
+	values := encodeUint256Array(100)
+
+	data := append([]byte{}, make([]byte, 64)...)
+	big.NewInt(64).FillBytes(data[0:32])
+	big.NewInt(int64(64+len(ids))).FillBytes(data[32:64])
+	data = append(data, ids...)
+	data = append(data, values...)
+
+	if _, _, err := decodeTransferBatchData(data); err == nil {
+		t.Fatal("expected an error for mismatched id/value counts")
+	}
+}
+
+func TestDecodeTransferBatchData_TooShort(t *testing.T) {
+	if _, _, err := decodeTransferBatchData(make([]byte, 32)); err == nil {
+		t.Fatal("expected an error for undersized log data")
+	}
+}
@@ -0,0 +1,146 @@
+package rpc
+
+import (
+	"math/big"
+	"testing"
+)
+
+// encodeAbiString builds a canonical ABI `string` encoding (offset + length + padded body)
+// for use as test fixture data.
+func encodeAbiString(s string) []byte {
+	word := func(n int64) []byte {
+		b := make([]byte, 32)
+		big.NewInt(n).FillBytes(b)
+		return b
+	}
+
+	body := []byte(s)
+	padded := len(body)
+	if r := padded % 32; r != 0 {
+		padded += 32 - r
+	}
+	if padded == 0 {
+		padded = 32
+	}
+	buf := make([]byte, padded)
+	copy(buf, body)
+
+	out := append([]byte{}, word(32)...)
+	out = append(out, word(int64(len(body)))...)
+	out = append(out, buf...)
+	return out
+}
+
+func TestParseAbiString(t *testing.T) {
+	data := encodeAbiString("Maker")
+
+	name, err := parseAbiString(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if name != "Maker" {
+		t.Fatalf("expected 'Maker', got %q", name)
+	}
+}
+
+func TestParseAbiString_Malformed(t *testing.T) {
+	tests := map[string]func() []byte{
+		"too short": func() []byte {
+			return make([]byte, 10)
+		},
+		"offset beyond data": func() []byte {
+			b := make([]byte, 64)
+			big.NewInt(1000).FillBytes(b[:32])
+			return b
+		},
+		"length overflows data": func() []byte {
+			b := make([]byte, 64)
+			big.NewInt(32).FillBytes(b[:32])
+			big.NewInt(1000).FillBytes(b[32:64])
+			return b
+		},
+		"length exceeds 1MB cap": func() []byte {
+			b := make([]byte, 64)
+			big.NewInt(32).FillBytes(b[:32])
+			big.NewInt(maxAbiStringLength + 1).FillBytes(b[32:64])
+			return b
+		},
+		"offset near int64 overflow": func() []byte {
+			b := make([]byte, 64)
+			// within the int64 range accepted by the BitLen() <= 63 check, but large
+			// enough that offset+32 wraps around as a machine int if added unchecked.
+			big.NewInt(1<<63 - 16).FillBytes(b[:32])
+			return b
+		},
+	}
+
+	for name, build := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := parseAbiString(build()); err == nil {
+				t.Fatalf("expected an error for %s", name)
+			}
+		})
+	}
+}
+
+func TestParseAbiName_CanonicalString(t *testing.T) {
+	data := encodeAbiString("Maker")
+
+	name, err := parseAbiName(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if name != "Maker" {
+		t.Fatalf("expected 'Maker', got %q", name)
+	}
+}
+
+func TestParseAbiName_Bytes32Fallback(t *testing.T) {
+	data := make([]byte, 32)
+	copy(data, "MKR")
+
+	name, err := parseAbiName(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if name != "MKR" {
+		t.Fatalf("expected 'MKR', got %q", name)
+	}
+}
+
+func TestParseAbiName_InsufficientData(t *testing.T) {
+	if _, err := parseAbiName(make([]byte, 10)); err == nil {
+		t.Fatal("expected an error for undersized payload")
+	}
+}
+
+func TestParseAbiDecimals(t *testing.T) {
+	data := make([]byte, 32)
+	data[31] = 18
+
+	dec, err := parseAbiDecimals(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if dec != 18 {
+		t.Fatalf("expected 18, got %d", dec)
+	}
+}
+
+func TestParseAbiDecimals_OutOfRange(t *testing.T) {
+	data := make([]byte, 32)
+	big.NewInt(256).FillBytes(data)
+
+	if _, err := parseAbiDecimals(data); err == nil {
+		t.Fatal("expected an error for an out-of-range decimals value")
+	}
+}
+
+func TestTrimBytes32(t *testing.T) {
+	data := make([]byte, 32)
+	copy(data, "DAI")
+
+	if got := trimBytes32(data); got != "DAI" {
+		t.Fatalf("expected 'DAI', got %q", got)
+	}
+}
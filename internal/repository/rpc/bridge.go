@@ -0,0 +1,63 @@
+/*
+Package rpc implements bridge to Opera full node API interface.
+
+We recommend using local IPC for fast and the most efficient inter-process communication between the API server
+and an Opera/Opera node. Any remote RPC connection will work, but the performance may be significantly degraded
+by extra networking overhead of remote RPC calls.
+
+You should also consider security implications of opening Opera RPC interface for a remote access.
+If you considering it as your deployment strategy, you should establish encrypted channel between the API server
+and Opera RPC interface with connection limited to specified endpoints.
+
+We strongly discourage opening Opera RPC interface for unrestricted Internet access.
+*/package rpc
+
+import (
+	"context"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	"math/big"
+)
+
+// rpcClient is the subset of go-ethereum's *rpc.Client API the bridge depends on, kept
+// narrow so tests can supply a fake without dialing a live node.
+type rpcClient interface {
+	CallContext(ctx context.Context, result interface{}, method string, args ...interface{}) error
+}
+
+// ethClient is the subset of go-ethereum's *ethclient.Client API the bridge depends on
+// for the parts of gas estimation and NFT contract probing that go through go-ethereum's
+// bind-style interfaces rather than a raw `ftm_`/`eth_` RPC call.
+type ethClient interface {
+	BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error)
+	CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error)
+	HeaderByNumber(ctx context.Context, number *big.Int) (*gethtypes.Header, error)
+	CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error)
+	FilterLogs(ctx context.Context, query ethereum.FilterQuery) ([]gethtypes.Log, error)
+}
+
+// logger is the subset of the server's structured logger the bridge depends on.
+type logger interface {
+	Debugf(format string, args ...interface{})
+	Error(args ...interface{})
+	Errorf(format string, args ...interface{})
+}
+
+// FtmBridge implements the bridge to an Opera/Lachesis full node consumed by the repository
+// to resolve on-chain data.
+type FtmBridge struct {
+	rpc       rpcClient
+	eth       ethClient
+	log       logger
+	rpcGasCap uint64
+}
+
+// NewFtmBridge creates a new Opera RPC bridge bound to the given low level RPC/eth clients
+// and logger. rpcGasCap is the operator's RPCGlobalGasCap setting from the server's own
+// configuration (0 falls back to the bridge's own package default) - the bridge takes the
+// already-resolved value rather than a configuration type of its own, so wiring a new knob
+// through here never requires this package to know about the rest of the server's config.
+func NewFtmBridge(rc rpcClient, ec ethClient, log logger, rpcGasCap uint64) *FtmBridge {
+	return &FtmBridge{rpc: rc, eth: ec, log: log, rpcGasCap: rpcGasCap}
+}
@@ -0,0 +1,46 @@
+package rpc
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestWithDefaultDeadline_AppliesWhenAbsent(t *testing.T) {
+	ctx, cancel := withDefaultDeadline(context.Background(), time.Second)
+	defer cancel()
+
+	if _, ok := ctx.Deadline(); !ok {
+		t.Fatal("expected a deadline to be applied")
+	}
+}
+
+func TestWithDefaultDeadline_RespectsCallerDeadline(t *testing.T) {
+	parent, parentCancel := context.WithTimeout(context.Background(), time.Minute)
+	defer parentCancel()
+
+	want, _ := parent.Deadline()
+
+	ctx, cancel := withDefaultDeadline(parent, time.Second)
+	defer cancel()
+
+	got, ok := ctx.Deadline()
+	if !ok {
+		t.Fatal("expected the caller's deadline to be preserved")
+	}
+	if !got.Equal(want) {
+		t.Fatalf("expected deadline %v, got %v", want, got)
+	}
+}
+
+func TestWithDefaultDeadline_NilContext(t *testing.T) {
+	ctx, cancel := withDefaultDeadline(nil, time.Second)
+	defer cancel()
+
+	if ctx == nil {
+		t.Fatal("expected a non-nil context")
+	}
+	if _, ok := ctx.Deadline(); !ok {
+		t.Fatal("expected a deadline to be applied for a nil context")
+	}
+}
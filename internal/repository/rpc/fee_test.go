@@ -0,0 +1,78 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"math/big"
+	"testing"
+)
+
+// unsupportedTipRpcClient simulates a node that does not implement
+// `eth_maxPriorityFeePerGas` and fails any other call, so a test using it can assert
+// that no RPC round trip is made beyond the dedicated tip probe.
+type unsupportedTipRpcClient struct {
+	calls int
+}
+
+func (f *unsupportedTipRpcClient) CallContext(ctx context.Context, result interface{}, method string, args ...interface{}) error {
+	f.calls++
+	return fmt.Errorf("%s not supported", method)
+}
+
+func TestMaxPriorityFeePerGasFromHistory_FallsBackWithoutRefetching(t *testing.T) {
+	rc := &unsupportedTipRpcClient{}
+	ftm := NewFtmBridge(rc, nil, fakeLogger{}, 0)
+
+	hist := &FeeHistory{Reward: [][]hexutil.Big{{bigToHex(7)}}}
+
+	tip, err := ftm.MaxPriorityFeePerGasFromHistory(context.Background(), hist)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if tip.ToInt().Cmp(big.NewInt(7)) != 0 {
+		t.Fatalf("expected the tip derived from the given history (7), got %s", tip.ToInt().String())
+	}
+	if rc.calls != 1 {
+		t.Fatalf("expected a single RPC call (the tip probe), got %d", rc.calls)
+	}
+}
+
+func bigToHex(v int64) hexutil.Big {
+	return hexutil.Big(*big.NewInt(v))
+}
+
+func TestMedianReward_PicksMostRecentNonEmptyBucket(t *testing.T) {
+	hist := &FeeHistory{
+		Reward: [][]hexutil.Big{
+			{bigToHex(1)},
+			{},
+			{bigToHex(3)},
+		},
+	}
+
+	got := medianReward(hist)
+	if got.ToInt().Cmp(big.NewInt(3)) != 0 {
+		t.Fatalf("expected 3, got %s", got.ToInt().String())
+	}
+}
+
+func TestMedianReward_AllEmpty(t *testing.T) {
+	hist := &FeeHistory{
+		Reward: [][]hexutil.Big{{}, {}},
+	}
+
+	got := medianReward(hist)
+	if got.ToInt().Sign() != 0 {
+		t.Fatalf("expected zero value, got %s", got.ToInt().String())
+	}
+}
+
+func TestMedianReward_NoHistory(t *testing.T) {
+	hist := &FeeHistory{}
+
+	got := medianReward(hist)
+	if got.ToInt().Sign() != 0 {
+		t.Fatalf("expected zero value, got %s", got.ToInt().String())
+	}
+}
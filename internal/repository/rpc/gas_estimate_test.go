@@ -0,0 +1,114 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	"math/big"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// fakeRpcClient stubs rpcClient for the binary search: "ftm_call" succeeds once its gas
+// argument is at or above threshold, and fails with an out-of-gas style error below it.
+// If capErr is set, a call at exactly capGas returns it once the gas has already been
+// probed successfully, simulating a revert only surfaced on the cap-hit replay call.
+type fakeRpcClient struct {
+	threshold uint64
+	capGas    uint64
+	capErr    error
+	capCalls  int
+}
+
+func (f *fakeRpcClient) CallContext(ctx context.Context, result interface{}, method string, args ...interface{}) error {
+	if method != "ftm_call" {
+		return nil
+	}
+
+	gasField := reflect.ValueOf(args[0]).FieldByName("Gas").Interface().(*hexutil.Uint64)
+	gas := uint64(*gasField)
+
+	if f.capErr != nil && gas == f.capGas {
+		f.capCalls++
+		if f.capCalls > 1 {
+			return f.capErr
+		}
+	}
+
+	if gas < f.threshold {
+		return fmt.Errorf("out of gas")
+	}
+
+	return nil
+}
+
+// fakeEthClient stubs ethClient, reporting a fixed block gas limit and no balance bound.
+type fakeEthClient struct {
+	gasLimit uint64
+}
+
+func (f *fakeEthClient) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error) {
+	return big.NewInt(0), nil
+}
+
+func (f *fakeEthClient) CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error) {
+	return nil, nil
+}
+
+func (f *fakeEthClient) HeaderByNumber(ctx context.Context, number *big.Int) (*gethtypes.Header, error) {
+	return &gethtypes.Header{GasLimit: f.gasLimit}, nil
+}
+
+func (f *fakeEthClient) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	return nil, nil
+}
+
+func (f *fakeEthClient) FilterLogs(ctx context.Context, query ethereum.FilterQuery) ([]gethtypes.Log, error) {
+	return nil, nil
+}
+
+// fakeLogger stubs logger, discarding every message.
+type fakeLogger struct{}
+
+func (fakeLogger) Debugf(format string, args ...interface{}) {}
+func (fakeLogger) Error(args ...interface{})                 {}
+func (fakeLogger) Errorf(format string, args ...interface{}) {}
+
+func TestGasEstimateWithCap_ConvergesOnThreshold(t *testing.T) {
+	ftm := NewFtmBridge(
+		&fakeRpcClient{threshold: 50_000},
+		&fakeEthClient{gasLimit: 30_000_000},
+		fakeLogger{},
+		0,
+	)
+
+	val, err := ftm.GasEstimateWithCap(context.Background(), &gasEstimateTrx{}, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if uint64(*val) != 50_000 {
+		t.Fatalf("expected the search to converge on 50000, got %d", uint64(*val))
+	}
+}
+
+func TestGasEstimateWithCap_SurfacesRevertOnCapHit(t *testing.T) {
+	revertErr := fmt.Errorf("execution reverted: custom revert reason")
+	ftm := NewFtmBridge(
+		&fakeRpcClient{threshold: 100_000, capGas: 100_000, capErr: revertErr},
+		&fakeEthClient{gasLimit: 1_000_000},
+		fakeLogger{},
+		100_000,
+	)
+
+	_, err := ftm.GasEstimateWithCap(context.Background(), &gasEstimateTrx{}, 0)
+	if err == nil {
+		t.Fatal("expected the replay call's revert reason to be surfaced")
+	}
+	if !strings.Contains(err.Error(), "custom revert reason") {
+		t.Fatalf("expected the revert reason to be surfaced, got: %s", err.Error())
+	}
+}
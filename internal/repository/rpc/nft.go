@@ -0,0 +1,108 @@
+/*
+Package rpc implements bridge to Opera full node API interface.
+
+We recommend using local IPC for fast and the most efficient inter-process communication between the API server
+and an Opera/Opera node. Any remote RPC connection will work, but the performance may be significantly degraded
+by extra networking overhead of remote RPC calls.
+
+You should also consider security implications of opening Opera RPC interface for a remote access.
+If you considering it as your deployment strategy, you should establish encrypted channel between the API server
+and Opera RPC interface with connection limited to specified endpoints.
+
+We strongly discourage opening Opera RPC interface for unrestricted Internet access.
+*/package rpc
+
+import (
+	"context"
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	"math/big"
+	"strings"
+)
+
+// solidityCallFunctionSupportsInterface is the identifier for a call to the ERC-165
+// Solidity function supportsInterface(bytes4) view returns(bool)
+var solidityCallFunctionSupportsInterface = common.Hex2Bytes("01ffc9a7")
+
+// erc721InterfaceId is the ERC-165 interface id of the ERC-721 standard.
+var erc721InterfaceId = common.Hex2Bytes("80ac58cd")
+
+// erc1155InterfaceId is the ERC-165 interface id of the ERC-1155 standard.
+var erc1155InterfaceId = common.Hex2Bytes("d9b67a26")
+
+// DetectNftContractType probes the contract at the given address via ERC-165
+// `supportsInterface` to tell an ERC-1155 semi-fungible contract apart from
+// a regular ERC-721 one.
+func (ftm *FtmBridge) DetectNftContractType(ctx context.Context, adr *common.Address) (types.NftContractType, error) {
+	ctx, cancel := withDefaultDeadline(ctx, defaultTokenNameAttemptTimeout)
+	defer cancel()
+
+	is1155, err := ftm.supportsInterface(ctx, adr, erc1155InterfaceId)
+	if err != nil {
+		return "", err
+	}
+	if is1155 {
+		return types.NftContractTypeErc1155, nil
+	}
+
+	is721, err := ftm.supportsInterface(ctx, adr, erc721InterfaceId)
+	if err != nil {
+		return "", err
+	}
+	if is721 {
+		return types.NftContractTypeErc721, nil
+	}
+
+	return "", fmt.Errorf("contract %s does not implement a supported NFT interface", adr.String())
+}
+
+// supportsInterface calls ERC-165 supportsInterface(bytes4) for the given interface id.
+// A reverting call is treated as "not supported" rather than a hard error, since
+// implementing ERC-165 itself is optional; a context or transport failure is propagated
+// instead, so a flaky/unreachable node is not misreported as an unsupported interface.
+func (ftm *FtmBridge) supportsInterface(ctx context.Context, adr *common.Address, interfaceId []byte) (bool, error) {
+	data := make([]byte, 0, len(solidityCallFunctionSupportsInterface)+32)
+	data = append(data, solidityCallFunctionSupportsInterface...)
+
+	arg := make([]byte, 32)
+	copy(arg, interfaceId)
+	data = append(data, arg...)
+
+	out, err := ftm.eth.CallContract(ctx, ethereum.CallMsg{
+		From: common.Address{},
+		To:   adr,
+		Data: data,
+	}, nil)
+	if err != nil {
+		if ctx.Err() != nil || !isRevertErr(err) {
+			return false, err
+		}
+		return false, nil
+	}
+
+	return len(out) >= 32 && out[31] == 1, nil
+}
+
+// FilterNftTransferLogs pulls the raw event logs carrying any of the given topics (typically
+// the ERC-721/1155 transfer event signatures) between fromBlock and toBlock, inclusive, across
+// all contracts. The caller is expected to decode and process each returned log itself.
+func (ftm *FtmBridge) FilterNftTransferLogs(ctx context.Context, fromBlock *big.Int, toBlock *big.Int, topics []common.Hash) ([]gethtypes.Log, error) {
+	return ftm.eth.FilterLogs(ctx, ethereum.FilterQuery{
+		FromBlock: fromBlock,
+		ToBlock:   toBlock,
+		Topics:    [][]common.Hash{topics},
+	})
+}
+
+// isRevertErr recognizes an EVM revert, as opposed to a transport or context failure, so
+// a contract that simply does not implement ERC-165 can be told apart from a node that is
+// unreachable or timed out.
+func isRevertErr(err error) bool {
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "revert") ||
+		strings.Contains(msg, "invalid opcode") ||
+		strings.Contains(msg, "invalid jump destination")
+}
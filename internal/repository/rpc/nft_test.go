@@ -0,0 +1,67 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	"math/big"
+	"testing"
+)
+
+func TestIsRevertErr(t *testing.T) {
+	tests := map[string]bool{
+		"execution reverted":           true,
+		"execution reverted: bad call": true,
+		"invalid opcode: opcode 0xfe":  true,
+		"invalid jump destination":     true,
+		"context deadline exceeded":    false,
+		"connection refused":           false,
+		"out of gas":                   false,
+	}
+
+	for msg, want := range tests {
+		if got := isRevertErr(errors.New(msg)); got != want {
+			t.Errorf("isRevertErr(%q) = %v, want %v", msg, got, want)
+		}
+	}
+}
+
+// transportFailingEthClient stubs ethClient, always failing CallContract with a non-revert,
+// transport-style error.
+type transportFailingEthClient struct{}
+
+func (transportFailingEthClient) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error) {
+	return nil, nil
+}
+
+func (transportFailingEthClient) CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error) {
+	return nil, nil
+}
+
+func (transportFailingEthClient) HeaderByNumber(ctx context.Context, number *big.Int) (*gethtypes.Header, error) {
+	return nil, nil
+}
+
+func (transportFailingEthClient) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	return nil, fmt.Errorf("connection refused")
+}
+
+func (transportFailingEthClient) FilterLogs(ctx context.Context, query ethereum.FilterQuery) ([]gethtypes.Log, error) {
+	return nil, nil
+}
+
+func TestDetectNftContractType_PropagatesTransportError(t *testing.T) {
+	ftm := NewFtmBridge(nil, transportFailingEthClient{}, fakeLogger{}, 0)
+
+	var adr common.Address
+	_, err := ftm.DetectNftContractType(context.Background(), &adr)
+	if err == nil {
+		t.Fatal("expected the transport error to be propagated")
+	}
+	if err.Error() != "connection refused" {
+		t.Fatalf("expected the raw transport error, got: %s", err.Error())
+	}
+}
@@ -30,8 +30,50 @@ var maxAcceptedGasPrice = big.NewInt(1_000_000_000_000_000_000)
 // solidityCallFunctionName is the identifier for a call to Solidity function name() view returns(string)
 var solidityCallFunctionName = common.Hex2Bytes("06fdde03")
 
+// solidityCallFunctionSymbol is the identifier for a call to Solidity function symbol() view returns(string)
+var solidityCallFunctionSymbol = common.Hex2Bytes("95d89b41")
+
+// solidityCallFunctionDecimals is the identifier for a call to Solidity function decimals() view returns(uint8)
+var solidityCallFunctionDecimals = common.Hex2Bytes("313ce567")
+
+// defaultRpcGlobalGasCap is used to bound the gas estimation binary search when the operator
+// did not configure RPCGlobalGasCap, mirroring go-ethereum's own fallback.
+const defaultRpcGlobalGasCap = uint64(50_000_000)
+
+// minGasAllowance is the lowest possible gas allowance a transaction can be estimated with,
+// equal to the intrinsic cost of a plain value transfer.
+const minGasAllowance = uint64(21_000)
+
+// defaultTokenNameAttemptTimeout bounds a TokenNameAttempt call when the caller's context
+// carries no deadline of its own.
+const defaultTokenNameAttemptTimeout = 5 * time.Second
+
+// defaultGasEstimateTimeout bounds a GasEstimate binary search when the caller's context
+// carries no deadline of its own; the search can issue many `ftm_call` round trips.
+const defaultGasEstimateTimeout = 15 * time.Second
+
+// withDefaultDeadline returns ctx as-is if it already carries a deadline, or a derived
+// context bounded by d otherwise, so a caller-supplied deadline always takes precedence.
+func withDefaultDeadline(ctx context.Context, d time.Duration) (context.Context, context.CancelFunc) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if _, ok := ctx.Deadline(); ok {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, d)
+}
+
+// gasEstimateTrx describes a transaction to be used for gas estimation.
+type gasEstimateTrx = struct {
+	From  *common.Address
+	To    *common.Address
+	Value *hexutil.Big
+	Data  *string
+}
+
 // GasPrice pulls the current amount of WEI for single Gas.
-func (ftm *FtmBridge) GasPrice() (hexutil.Big, error) {
+func (ftm *FtmBridge) GasPrice(ctx context.Context) (hexutil.Big, error) {
 	// keep track of the operation
 	ftm.log.Debugf("checking current gas price")
 
@@ -39,7 +81,7 @@ func (ftm *FtmBridge) GasPrice() (hexutil.Big, error) {
 	var price hexutil.Big
 	var try uint8
 	for {
-		err := ftm.rpc.Call(&price, "ftm_gasPrice")
+		err := ftm.rpc.CallContext(ctx, &price, "ftm_gasPrice")
 		if err != nil {
 			ftm.log.Error("current gas price could not be obtained")
 			return price, err
@@ -57,101 +99,366 @@ func (ftm *FtmBridge) GasPrice() (hexutil.Big, error) {
 		}
 
 		try++
-		time.Sleep(100 * time.Millisecond)
+		select {
+		case <-ctx.Done():
+			// a canceled/expired context breaks out of the back-off immediately
+			return price, ctx.Err()
+		case <-time.After(100 * time.Millisecond):
+		}
 	}
 
 	return price, nil
 }
 
-// GasEstimate calculates the estimated amount of Gas required to perform
-// transaction described by the input params.
-func (ftm *FtmBridge) GasEstimate(trx *struct {
-	From  *common.Address
-	To    *common.Address
-	Value *hexutil.Big
-	Data  *string
-}) (*hexutil.Uint64, error) {
+// GasEstimate calculates the estimated amount of Gas required to perform the transaction
+// described by the input params using a binary search bounded by the block gas limit,
+// the sender's balance and the configured RPCGlobalGasCap (mirrors the go-ethereum
+// `eth_estimateGas` search so refund-heavy operations such as SSTORE clearing or a CALL
+// stipend are not under-estimated by a single `ftm_estimateGas` round trip).
+func (ftm *FtmBridge) GasEstimate(ctx context.Context, trx *gasEstimateTrx) (*hexutil.Uint64, error) {
+	return ftm.GasEstimateWithCap(ctx, trx, 0)
+}
+
+// GasEstimateWithCap calculates the estimated amount of Gas the same way as GasEstimate,
+// but lets the caller tighten the search convergence window with epsilonPct (the search
+// stops once the gap between the highest known failing and lowest known passing gas amount
+// is within epsilonPct of the upper bound; epsilonPct <= 0 keeps the default hi-lo <= 1 window).
+func (ftm *FtmBridge) GasEstimateWithCap(ctx context.Context, trx *gasEstimateTrx, epsilonPct float64) (*hexutil.Uint64, error) {
 	// keep track of the operation
-	ftm.log.Debugf("calling for gas amount estimation")
+	ftm.log.Debugf("estimating gas via binary search")
 
-	var val hexutil.Uint64
-	err := ftm.rpc.Call(&val, "ftm_estimateGas", trx)
-	if err != nil {
-		// missing required argument? incompatibility between old and new RPC API
-		if strings.Contains(err.Error(), "missing value") {
-			return ftm.GasEstimateWithBlock(trx)
+	ctx, cancel := withDefaultDeadline(ctx, defaultGasEstimateTimeout)
+	defer cancel()
+
+	if err := ftm.sanityCheckGasEstimateInput(ctx, trx); err != nil {
+		return nil, err
+	}
+
+	lo := minGasAllowance
+	if trx.Data != nil {
+		if ig := intrinsicGasOf(*trx.Data); ig > lo {
+			lo = ig
 		}
+	}
 
-		// return error
-		ftm.log.Errorf("can not estimate gas; %s", err.Error())
+	cap := ftm.gasCap()
+	hi, err := ftm.gasEstimateUpperBound(ctx, trx, cap)
+	if err != nil {
+		ftm.log.Errorf("can not determine gas estimate upper bound; %s", err.Error())
 		return nil, err
 	}
 
+	if lo > hi {
+		return nil, fmt.Errorf("gas required exceeds allowance (%d)", hi)
+	}
+
+	// make sure the upper bound is executable at all; a non-gas failure (e.g. a revert) is
+	// surfaced to the caller as-is, a plain out-of-gas at the bound yields the allowance error
+	if ok, exErr := ftm.gasEstimateExecutable(ctx, trx, hi); exErr != nil || !ok {
+		if exErr != nil {
+			return nil, exErr
+		}
+		return nil, fmt.Errorf("gas required exceeds allowance (%d)", hi)
+	}
+
+	threshold := uint64(1)
+	if epsilonPct > 0 {
+		if t := uint64(float64(hi) * epsilonPct / 100); t > threshold {
+			threshold = t
+		}
+	}
+
+	for hi-lo > threshold {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		mid := (hi + lo) / 2
+
+		ok, exErr := ftm.gasEstimateExecutable(ctx, trx, mid)
+		if exErr != nil {
+			ftm.log.Errorf("can not estimate gas; %s", exErr.Error())
+			return nil, exErr
+		}
+
+		if ok {
+			hi = mid
+		} else {
+			lo = mid
+		}
+	}
+
+	// we converged right on the configured cap; run one last call so the caller
+	// gets the actual revert reason instead of a silent cap rejection
+	if hi == cap {
+		if _, exErr := ftm.gasEstimateExecutable(ctx, trx, hi); exErr != nil {
+			return nil, exErr
+		}
+	}
+
+	val := hexutil.Uint64(hi)
 	return &val, nil
 }
 
-// GasEstimateWithBlock calculates the estimated amount of Gas required to perform
-// transaction described by the input params with specifying the block on which the calculation
-// should happen (new RPC API compatibility).
-// @TODO Replace the old gas estimate call once the API gets upgraded on all nodes.
-func (ftm *FtmBridge) GasEstimateWithBlock(trx *struct {
-	From  *common.Address
-	To    *common.Address
-	Value *hexutil.Big
-	Data  *string
-}) (*hexutil.Uint64, error) {
-	// keep track of the operation
-	ftm.log.Debugf("calling for gas amount estimation with block details")
+// sanityCheckGasEstimateInput short-circuits obviously invalid estimation inputs
+// before the (expensive) binary search is started.
+func (ftm *FtmBridge) sanityCheckGasEstimateInput(ctx context.Context, trx *gasEstimateTrx) error {
+	if trx.From != nil && trx.Value != nil && trx.Value.ToInt().Sign() > 0 {
+		balance, err := ftm.eth.BalanceAt(ctx, *trx.From, nil)
+		if err == nil && balance.Cmp(trx.Value.ToInt()) < 0 {
+			return fmt.Errorf("insufficient funds for transfer")
+		}
+	}
+
+	if trx.To != nil && trx.Data != nil && len(*trx.Data) > 2 {
+		code, err := ftm.eth.CodeAt(ctx, *trx.To, nil)
+		if err == nil && len(code) == 0 {
+			return fmt.Errorf("no contract code at given address")
+		}
+	}
+
+	return nil
+}
+
+// gasEstimateUpperBound resolves the highest gas amount the binary search is allowed to try:
+// the configured/global gas cap, further bounded by the current block gas limit
+// and by what the sender can actually afford at the current gas price.
+func (ftm *FtmBridge) gasEstimateUpperBound(ctx context.Context, trx *gasEstimateTrx, cap uint64) (uint64, error) {
+	hi := cap
+
+	header, err := ftm.eth.HeaderByNumber(ctx, nil)
+	if err == nil && header.GasLimit < hi {
+		hi = header.GasLimit
+	}
+
+	if trx.From != nil {
+		price, prErr := ftm.GasPrice(ctx)
+		if prErr == nil {
+			balance, blErr := ftm.eth.BalanceAt(ctx, *trx.From, nil)
+			if blErr == nil {
+				value := big.NewInt(0)
+				if trx.Value != nil {
+					value = trx.Value.ToInt()
+				}
+
+				allowance, ok, alErr := gasAllowance(balance, value, price.ToInt())
+				if alErr != nil {
+					return 0, alErr
+				}
+				if ok && allowance < hi {
+					hi = allowance
+				}
+			}
+		}
+	}
 
-	var val hexutil.Uint64
-	err := ftm.rpc.Call(&val, "ftm_estimateGas", trx, BlockTypeLatest)
+	return hi, nil
+}
+
+// gasAllowance computes how much gas the sender can afford at the given price once the
+// transaction's value (both balance and value are wei-denominated) has been reserved,
+// mirroring go-ethereum's own `balance - value, then / price` order of operations.
+// ok is false when price is not positive, meaning the allowance cannot be bounded this way.
+func gasAllowance(balance, value, price *big.Int) (allowance uint64, ok bool, err error) {
+	if price == nil || price.Sign() <= 0 {
+		return 0, false, nil
+	}
+
+	available := new(big.Int).Sub(balance, value)
+	if available.Sign() < 0 {
+		return 0, false, fmt.Errorf("insufficient funds for gas * price + value")
+	}
+
+	gas := new(big.Int).Div(available, price)
+	if !gas.IsUint64() {
+		return 0, false, nil
+	}
+
+	return gas.Uint64(), true, nil
+}
+
+// gasCap returns the configured global gas cap used to bound the estimation search,
+// or the package default if the operator did not set one.
+func (ftm *FtmBridge) gasCap() uint64 {
+	if ftm.rpcGasCap > 0 {
+		return ftm.rpcGasCap
+	}
+	return defaultRpcGlobalGasCap
+}
+
+// gasEstimateExecutable probes whether the given transaction succeeds with the provided
+// gas amount by calling `ftm_call` against the latest block.
+func (ftm *FtmBridge) gasEstimateExecutable(ctx context.Context, trx *gasEstimateTrx, gas uint64) (bool, error) {
+	g := hexutil.Uint64(gas)
+	call := struct {
+		From  *common.Address `json:"from,omitempty"`
+		To    *common.Address `json:"to,omitempty"`
+		Gas   *hexutil.Uint64 `json:"gas,omitempty"`
+		Value *hexutil.Big    `json:"value,omitempty"`
+		Data  *string         `json:"data,omitempty"`
+	}{trx.From, trx.To, &g, trx.Value, trx.Data}
+
+	var out hexutil.Bytes
+	err := ftm.rpc.CallContext(ctx, &out, "ftm_call", call, BlockTypeLatest)
 	if err != nil {
-		// return error
-		ftm.log.Errorf("can not estimate gas; %s", err.Error())
-		return nil, err
+		if isOutOfGasErr(err) {
+			return false, nil
+		}
+		return false, err
 	}
 
-	return &val, nil
+	return true, nil
+}
+
+// isOutOfGasErr recognizes the error shapes an out-of-gas or gas-starved revert
+// comes back as, so the binary search can treat it as "failed", not a hard error.
+func isOutOfGasErr(err error) bool {
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "out of gas") ||
+		strings.Contains(msg, "intrinsic gas too low") ||
+		strings.Contains(msg, "gas required exceeds allowance") ||
+		strings.Contains(msg, "gas uint64 overflow")
+}
+
+// intrinsicGasOf estimates the intrinsic gas cost of the given hex-encoded call data,
+// i.e. the 21000 base cost plus the per-byte cost of the payload (post EIP-2028 pricing).
+func intrinsicGasOf(hexData string) uint64 {
+	data, err := hexutil.Decode(hexData)
+	if err != nil || len(data) == 0 {
+		return minGasAllowance
+	}
+
+	gas := minGasAllowance
+	for _, b := range data {
+		if b == 0 {
+			gas += 4
+		} else {
+			gas += 16
+		}
+	}
+
+	return gas
 }
 
 // TokenNameAttempt tries to extract token name from the contract on the given address.
 // We assume to be able to call Solidity: function name() view returns(string)
-func (ftm *FtmBridge) TokenNameAttempt(adr *common.Address) (string, error) {
-	// call the function on the contract address
-	data, err := ftm.eth.CallContract(context.Background(), ethereum.CallMsg{
+func (ftm *FtmBridge) TokenNameAttempt(ctx context.Context, adr *common.Address) (string, error) {
+	data, err := ftm.tokenMetadataCall(ctx, adr, solidityCallFunctionName)
+	if err != nil {
+		return "", err
+	}
+
+	return parseAbiName(data)
+}
+
+// TokenSymbolAttempt tries to extract the token symbol from the contract on the given address.
+// We assume to be able to call Solidity: function symbol() view returns(string)
+func (ftm *FtmBridge) TokenSymbolAttempt(ctx context.Context, adr *common.Address) (string, error) {
+	data, err := ftm.tokenMetadataCall(ctx, adr, solidityCallFunctionSymbol)
+	if err != nil {
+		return "", err
+	}
+
+	return parseAbiName(data)
+}
+
+// TokenDecimalsAttempt tries to extract the number of decimals used by the token
+// on the given address. We assume to be able to call Solidity: function decimals() view returns(uint8)
+func (ftm *FtmBridge) TokenDecimalsAttempt(ctx context.Context, adr *common.Address) (uint8, error) {
+	data, err := ftm.tokenMetadataCall(ctx, adr, solidityCallFunctionDecimals)
+	if err != nil {
+		return 0, err
+	}
+
+	return parseAbiDecimals(data)
+}
+
+// tokenMetadataCall executes a no-argument, view-only token metadata call (name/symbol/decimals)
+// bounded by the default token metadata call timeout.
+func (ftm *FtmBridge) tokenMetadataCall(ctx context.Context, adr *common.Address, selector []byte) ([]byte, error) {
+	ctx, cancel := withDefaultDeadline(ctx, defaultTokenNameAttemptTimeout)
+	defer cancel()
+
+	return ftm.eth.CallContract(ctx, ethereum.CallMsg{
 		From: common.Address{},
 		To:   adr,
-		Data: solidityCallFunctionName,
+		Data: selector,
 	}, nil)
-	if err != nil {
-		return "", err
+}
+
+// maxAbiStringLength bounds the length an ABI encoded string payload is allowed to declare,
+// rejecting clearly malformed responses before we try to slice into them.
+const maxAbiStringLength = 1 << 20 // 1MB
+
+// parseAbiName decodes a token metadata string (name/symbol), trying the canonical ABI
+// `string` layout first and falling back to a plain 32-byte `bytes32` payload for early-era
+// tokens (MKR being the canonical example) that predate the ABI string encoding. Any other
+// payload rejected by the string decoding is reported as an error.
+func parseAbiName(data []byte) (string, error) {
+	name, err := parseAbiString(data)
+	if err == nil {
+		return name, nil
+	}
+
+	if len(data) == 32 {
+		return trimBytes32(data), nil
 	}
 
-	return parseAbiString(data)
+	return "", err
 }
 
-// parseAbiString decodes a string in ABI format, if possible.
+// parseAbiString decodes a string in the canonical ABI format (offset + length + body),
+// rejecting offsets/lengths that would read outside of the received payload.
 func parseAbiString(data []byte) (string, error) {
-	// we expect string in response => offset + string length + string body padded to 32 bytes (at least 3x32 bytes)
-	if nil == data || len(data) < 96 {
+	// we expect string in response => offset + string length + string body padded to 32 bytes
+	if nil == data || len(data) < 64 {
 		return "", fmt.Errorf("abi encoded string expected, only %d bytes received", len(data))
 	}
 
-	// read the offset of the actual string
+	// read the offset to the start of the length word
 	bigOffset := new(big.Int).SetBytes(data[:32])
 	if bigOffset.BitLen() > 63 {
 		return "", fmt.Errorf("string offset larger than int64: %v", bigOffset)
 	}
-
-	// the string data starts with the length, so add another 32 bytes to skip it
-	offset := int(bigOffset.Add(bigOffset, common.Big32).Uint64())
+	offset := int(bigOffset.Uint64())
+	if offset < 0 || offset > len(data)-32 {
+		return "", fmt.Errorf("string offset %d beyond the %d bytes received", offset, len(data))
+	}
 
 	// how long is the string?
-	bigLength := new(big.Int).SetBytes(data[offset-32 : offset])
+	bigLength := new(big.Int).SetBytes(data[offset : offset+32])
 	if bigLength.BitLen() > 63 {
 		return "", fmt.Errorf("string length larger than int64: %v", bigLength)
 	}
 	length := int(bigLength.Uint64())
+	if length > maxAbiStringLength {
+		return "", fmt.Errorf("string length %d exceeds the %d bytes limit", length, maxAbiStringLength)
+	}
+
+	start := offset + 32
+	if start+length > len(data) {
+		return "", fmt.Errorf("string of %d bytes overflows the %d bytes received", length, len(data))
+	}
+
+	return string(data[start : start+length]), nil
+}
+
+// parseAbiDecimals decodes a uint8 from an ABI-encoded decimals() response.
+func parseAbiDecimals(data []byte) (uint8, error) {
+	if len(data) == 0 {
+		return 0, fmt.Errorf("abi encoded decimals expected, no data received")
+	}
+
+	val := new(big.Int).SetBytes(data)
+	if !val.IsUint64() || val.Uint64() > 255 {
+		return 0, fmt.Errorf("decimals value out of uint8 range: %v", val)
+	}
+
+	return uint8(val.Uint64()), nil
+}
 
-	return string(data[offset : offset+length]), nil
+// trimBytes32 strips the null/space padding off a raw bytes32 payload, used as
+// the fallback decoding for token metadata calls that predate the ABI string standard.
+func trimBytes32(b []byte) string {
+	return strings.TrimRight(strings.TrimRight(string(b), "\x00"), " ")
 }
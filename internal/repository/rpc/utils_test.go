@@ -0,0 +1,93 @@
+package rpc
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+)
+
+func TestGasCap_DefaultsWhenUnconfigured(t *testing.T) {
+	ftm := NewFtmBridge(nil, nil, fakeLogger{}, 0)
+	if got := ftm.gasCap(); got != defaultRpcGlobalGasCap {
+		t.Fatalf("expected the package default %d, got %d", defaultRpcGlobalGasCap, got)
+	}
+}
+
+func TestGasCap_UsesConfiguredValue(t *testing.T) {
+	ftm := NewFtmBridge(nil, nil, fakeLogger{}, 12_345)
+	if got := ftm.gasCap(); got != 12_345 {
+		t.Fatalf("expected the configured cap 12345, got %d", got)
+	}
+}
+
+func TestIntrinsicGasOf(t *testing.T) {
+	// two zero bytes (4 gas each) + one non-zero byte (16 gas) on top of the base allowance
+	gas := intrinsicGasOf("0x000001")
+	if exp := minGasAllowance + 4 + 4 + 16; gas != exp {
+		t.Fatalf("expected %d, got %d", exp, gas)
+	}
+}
+
+func TestIntrinsicGasOf_Empty(t *testing.T) {
+	if gas := intrinsicGasOf("0x"); gas != minGasAllowance {
+		t.Fatalf("expected %d, got %d", minGasAllowance, gas)
+	}
+}
+
+func TestIsOutOfGasErr(t *testing.T) {
+	tests := map[string]bool{
+		"out of gas":                       true,
+		"intrinsic gas too low":            true,
+		"gas required exceeds allowance":   true,
+		"execution reverted":               false,
+		"no contract code at given address": false,
+	}
+
+	for msg, want := range tests {
+		if got := isOutOfGasErr(errors.New(msg)); got != want {
+			t.Errorf("isOutOfGasErr(%q) = %v, want %v", msg, got, want)
+		}
+	}
+}
+
+func TestGasAllowance(t *testing.T) {
+	balance := big.NewInt(1_000_000_000_000_000_000) // 1 FTM
+	value := big.NewInt(900_000_000_000_000_000)     // 0.9 FTM
+	price := big.NewInt(1_000_000_000)               // 1 Gwei
+
+	allowance, ok, err := gasAllowance(balance, value, price)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if !ok {
+		t.Fatal("expected the allowance to be usable")
+	}
+
+	// (balance - value) / price = 0.1 FTM / 1 Gwei = 100,000,000
+	if exp := uint64(100_000_000); allowance != exp {
+		t.Fatalf("expected %d, got %d", exp, allowance)
+	}
+}
+
+func TestGasAllowance_ValueExceedsBalance(t *testing.T) {
+	balance := big.NewInt(1_000_000_000_000_000_000)
+	value := big.NewInt(2_000_000_000_000_000_000)
+	price := big.NewInt(1_000_000_000)
+
+	if _, _, err := gasAllowance(balance, value, price); err == nil {
+		t.Fatal("expected an insufficient funds error")
+	}
+}
+
+func TestGasAllowance_ZeroPrice(t *testing.T) {
+	balance := big.NewInt(1_000_000_000_000_000_000)
+	value := big.NewInt(0)
+
+	_, ok, err := gasAllowance(balance, value, big.NewInt(0))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if ok {
+		t.Fatal("expected the allowance to be unusable for a zero price")
+	}
+}
@@ -0,0 +1,29 @@
+/*
+Package repository implements repository for handling fast and efficient access to data required
+by the resolvers of the API server.
+
+Internally it utilizes RPC to access Opera/Lachesis full node for blockchain interaction. Mongo database
+for fast, robust and scalable off-chain data storage, especially for aggregated and pre-calculated data mining
+results. BigCache for in-memory object storage to speed up loading of frequently accessed entities.
+*/package repository
+
+import (
+	"context"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// TokenNameAttempt resolves the name of the token deployed on the given contract address, if possible.
+func (p *proxy) TokenNameAttempt(ctx context.Context, adr *common.Address) (string, error) {
+	return p.rpc.TokenNameAttempt(ctx, adr)
+}
+
+// TokenSymbolAttempt resolves the symbol of the token deployed on the given contract address, if possible.
+func (p *proxy) TokenSymbolAttempt(ctx context.Context, adr *common.Address) (string, error) {
+	return p.rpc.TokenSymbolAttempt(ctx, adr)
+}
+
+// TokenDecimalsAttempt resolves the number of decimals used by the token deployed
+// on the given contract address, if possible.
+func (p *proxy) TokenDecimalsAttempt(ctx context.Context, adr *common.Address) (uint8, error) {
+	return p.rpc.TokenDecimalsAttempt(ctx, adr)
+}
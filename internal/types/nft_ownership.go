@@ -0,0 +1,41 @@
+// Package types defines common data structures used across the API server and its resolvers.
+package types
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"time"
+)
+
+// NftContractType distinguishes the token standard an NftOwnership record belongs to,
+// since ERC-721 and ERC-1155 contracts model ownership differently.
+type NftContractType string
+
+// NftContractTypeErc721 marks a single-owner-per-token NFT contract.
+const NftContractTypeErc721 NftContractType = "ERC721"
+
+// NftContractTypeErc1155 marks a semi-fungible, multi-owner-per-token NFT contract.
+const NftContractTypeErc1155 NftContractType = "ERC1155"
+
+// NftOwnership represents the ownership of an NFT token by an address.
+//
+// For ERC-721 contracts Qty is 1 for the current owner, since a token id can only have a
+// single owner; a former owner's record stays stored with Qty 0.
+// For ERC-1155 contracts Qty is the balance the owner holds of the given token id, kept
+// up to date by accumulating TransferSingle/TransferBatch events.
+type NftOwnership struct {
+	Contract     common.Address
+	TokenId      hexutil.Big
+	Owner        common.Address
+	Qty          hexutil.Big
+	ContractType NftContractType
+	Obtained     time.Time
+}
+
+// NftOwnershipList represents a paginated collection of NFT ownership records.
+type NftOwnershipList struct {
+	Collection []*NftOwnership
+	TotalCount hexutil.Big
+	IsStart    bool
+	IsEnd      bool
+}